@@ -0,0 +1,277 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dmoruzzi/sf-version-watch/notify"
+)
+
+// Result holds the outcome of checking a single instance's release number
+// against the expected version.
+type Result struct {
+	Instance  string
+	Expected  string
+	Got       string
+	Mode      MatchMode
+	Attempts  int
+	Duration  time.Duration
+	Err       error
+	Timestamp time.Time
+	matched   bool
+}
+
+// Matched reports whether the fetched release number matched what was
+// expected under the Result's MatchMode. It is always false when Err is set.
+func (r Result) Matched() bool {
+	return r.Err == nil && r.matched
+}
+
+// MarshalJSON renders Result for the /status endpoint; Err is flattened to
+// its string message since error values don't marshal meaningfully on their
+// own (mirrors notify.Event.MarshalJSON).
+func (r Result) MarshalJSON() ([]byte, error) {
+	type alias struct {
+		Instance  string    `json:"instance"`
+		Expected  string    `json:"expected"`
+		Got       string    `json:"got,omitempty"`
+		Mode      MatchMode `json:"mode"`
+		Attempts  int       `json:"attempts"`
+		Duration  string    `json:"duration"`
+		Error     string    `json:"error,omitempty"`
+		Matched   bool      `json:"matched"`
+		Timestamp time.Time `json:"timestamp"`
+	}
+	return json.Marshal(alias{
+		Instance:  r.Instance,
+		Expected:  r.Expected,
+		Got:       r.Got,
+		Mode:      r.Mode,
+		Attempts:  r.Attempts,
+		Duration:  r.Duration.String(),
+		Error:     errString(r.Err),
+		Matched:   r.Matched(),
+		Timestamp: r.Timestamp,
+	})
+}
+
+// toEvent converts a Result into the notify.Event shape, for sending to
+// configured notification sinks. It should only be called when the result is
+// an error or mismatch; callers check Matched() first.
+func (r Result) toEvent() notify.Event {
+	kind := notify.KindMismatch
+	if r.Err != nil {
+		kind = notify.KindError
+	}
+	return notify.Event{
+		Instance:  r.Instance,
+		Expected:  r.Expected,
+		Got:       r.Got,
+		Err:       r.Err,
+		Timestamp: r.Timestamp,
+		Kind:      kind,
+	}
+}
+
+// RetryPolicy configures the backoff used when fetchStatus fails with a
+// network error or a 5xx response.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// DefaultRetryPolicy is used by a zero-value Checker.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    30 * time.Second,
+}
+
+// Checker fetches and compares Salesforce instance release numbers. It is the
+// shared implementation behind both the one-shot CLI and the daemon mode, so
+// the two never drift in behavior.
+type Checker struct {
+	// HTTPClient is used to call the Salesforce status API. Defaults to
+	// http.DefaultClient when nil.
+	HTTPClient *http.Client
+	// Timeout bounds each individual fetch attempt. Zero means no timeout. A
+	// per-instance timeout passed to Check overrides this for that call.
+	Timeout time.Duration
+	// Retry configures the retry/backoff behavior for failed fetches. Zero
+	// value falls back to DefaultRetryPolicy.
+	Retry RetryPolicy
+}
+
+// NewChecker returns a Checker ready to use, with DefaultRetryPolicy and no
+// per-request timeout.
+func NewChecker() *Checker {
+	return &Checker{HTTPClient: http.DefaultClient, Retry: DefaultRetryPolicy}
+}
+
+// MinimalAPIResponse retrieves thereleaseNumber API response
+type MinimalAPIResponse struct {
+	ReleaseNumber string `json:"releaseNumber"`
+}
+
+// fetchStatus retrieves the release number for a given instance from the
+// Salesforce API, retrying on network errors and 5xx responses with
+// exponential backoff and jitter, honoring Retry-After when the API sends
+// one. It returns the number of attempts made alongside the usual result.
+// timeout, if non-zero, bounds each individual attempt rather than the whole
+// retry loop, so a single slow attempt can't starve the remaining retries.
+func (c *Checker) fetchStatus(ctx context.Context, instance string, timeout time.Duration) (release string, attempts int, err error) {
+	policy := c.Retry
+	if policy.MaxAttempts <= 0 {
+		policy = DefaultRetryPolicy
+	}
+
+	url := fmt.Sprintf("https://status.salesforce.com/api/instances/%s/status/preview?locale=en", instance)
+
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		attempts = attempt
+
+		release, retryAfter, attemptErr := c.attemptFetchWithTimeout(ctx, url, timeout)
+		if attemptErr == nil {
+			return release, attempts, nil
+		}
+		err = attemptErr
+
+		if attempt == policy.MaxAttempts {
+			break
+		}
+
+		delay := retryAfter
+		if delay <= 0 {
+			delay = backoff(policy, attempt)
+		}
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return "", attempts, ctx.Err()
+		}
+	}
+
+	return "", attempts, fmt.Errorf("giving up after %d attempts: %w", attempts, err)
+}
+
+// attemptFetchWithTimeout wraps attemptFetch with its own fresh timeout, so
+// the per-attempt budget resets on every retry instead of being shared across
+// the whole fetchStatus loop.
+func (c *Checker) attemptFetchWithTimeout(ctx context.Context, url string, timeout time.Duration) (release string, retryAfter time.Duration, err error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return c.attemptFetch(ctx, url)
+}
+
+// attemptFetch performs a single HTTP round-trip. retryAfter is non-zero when
+// the response carried a Retry-After header that should override the normal
+// backoff delay.
+func (c *Checker) attemptFetch(ctx context.Context, url string) (release string, retryAfter time.Duration, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	resp, err := c.client().Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to fetch status: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 500 {
+		return "", parseRetryAfter(resp.Header.Get("Retry-After")), fmt.Errorf("API returned non-OK status: %s", resp.Status)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", 0, fmt.Errorf("API returned non-OK status: %s", resp.Status)
+	}
+
+	var apiResponse MinimalAPIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
+		return "", 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return apiResponse.ReleaseNumber, 0, nil
+}
+
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if when, err := http.ParseTime(header); err == nil {
+		return time.Until(when)
+	}
+	return 0
+}
+
+// backoff computes the exponential-with-jitter delay before retry attempt.
+func backoff(policy RetryPolicy, attempt int) time.Duration {
+	delay := policy.BaseDelay << uint(attempt-1)
+	if delay > policy.MaxDelay || delay <= 0 {
+		delay = policy.MaxDelay
+	}
+	jitter := time.Duration(rand.Int63n(int64(delay)/2 + 1))
+	return delay/2 + jitter
+}
+
+func (c *Checker) client() *http.Client {
+	if c.HTTPClient == nil {
+		return http.DefaultClient
+	}
+	return c.HTTPClient
+}
+
+// Check fetches the current release number for instance and compares it
+// against expectedVersion under mode, returning a Result rather than a
+// free-form string so callers (CLI output, metrics, notifications) can each
+// format it how they need. timeout, if non-zero, overrides c.Timeout for this
+// call, letting an instance's per-instance config override the global
+// setting.
+func (c *Checker) Check(ctx context.Context, instance, expectedVersion string, mode MatchMode, timeout time.Duration) (result Result) {
+	start := time.Now()
+	result = Result{
+		Instance:  instance,
+		Expected:  expectedVersion,
+		Mode:      mode,
+		Timestamp: start,
+	}
+	defer func() { result.Duration = time.Since(start) }()
+
+	if timeout == 0 {
+		timeout = c.Timeout
+	}
+
+	releaseNumber, attempts, err := c.fetchStatus(ctx, instance, timeout)
+	result.Attempts = attempts
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.Got = releaseNumber
+
+	matched, err := matchRelease(mode, expectedVersion, releaseNumber)
+	if err != nil {
+		result.Err = fmt.Errorf("failed to evaluate match mode %q: %w", mode, err)
+		return result
+	}
+	result.matched = matched
+	return result
+}
+
+// compareReleaseNumbers compares the fetched release number with the expected version
+func compareReleaseNumbers(ctx context.Context, checker *Checker, instance, expectedVersion string, mode MatchMode, timeout time.Duration, wg *sync.WaitGroup, results chan<- Result) {
+	defer wg.Done()
+	results <- checker.Check(ctx, instance, expectedVersion, mode, timeout)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestAttemptFetchWithTimeout_AbandonsSlowAttempt verifies a single attempt
+// exceeding its timeout is abandoned promptly with an error, rather than
+// running to completion or blocking on ctx alone.
+func TestAttemptFetchWithTimeout_AbandonsSlowAttempt(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	c := &Checker{HTTPClient: server.Client()}
+
+	start := time.Now()
+	_, _, err := c.attemptFetchWithTimeout(context.Background(), server.URL, 20*time.Millisecond)
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("expected an error from a timed-out attempt, got nil")
+	}
+	if elapsed > 200*time.Millisecond {
+		t.Fatalf("attemptFetchWithTimeout took %v, want it to abandon the request near the 20ms timeout", elapsed)
+	}
+}
+
+// TestAttemptFetchWithTimeout_ResetsPerCall verifies the timeout passed to
+// attemptFetchWithTimeout applies fresh to each call, rather than being
+// consumed cumulatively across calls sharing a parent context - which is
+// exactly what lets fetchStatus's retry loop give every attempt its own full
+// budget instead of one timeout shared across all attempts.
+func TestAttemptFetchWithTimeout_ResetsPerCall(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(30 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"releaseNumber":"250.1.0"}`))
+	}))
+	defer server.Close()
+
+	c := &Checker{HTTPClient: server.Client()}
+	parent := context.Background()
+
+	for i := 0; i < 3; i++ {
+		release, _, err := c.attemptFetchWithTimeout(parent, server.URL, 100*time.Millisecond)
+		if err != nil {
+			t.Fatalf("attempt %d: unexpected error: %v", i+1, err)
+		}
+		if release != "250.1.0" {
+			t.Fatalf("attempt %d: release = %q, want %q", i+1, release, "250.1.0")
+		}
+	}
+}
+
+// TestBackoff verifies backoff stays within [0, MaxDelay] and grows with the
+// attempt number, without asserting exact values since jitter is random.
+func TestBackoff(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 10 * time.Millisecond, MaxDelay: 100 * time.Millisecond}
+
+	for attempt := 1; attempt <= 5; attempt++ {
+		delay := backoff(policy, attempt)
+		if delay < 0 || delay > policy.MaxDelay {
+			t.Fatalf("backoff(attempt=%d) = %v, want within [0, %v]", attempt, delay, policy.MaxDelay)
+		}
+	}
+}
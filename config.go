@@ -0,0 +1,253 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dmoruzzi/sf-version-watch/notify"
+	"gopkg.in/yaml.v3"
+)
+
+// CLI is the command-line interface parsed by kong. Every field can also be
+// set via environment variable or the config file named by --config;
+// precedence is CLI flag > environment variable > config file > default.
+type CLI struct {
+	Instance string `help:"Instance name(s) to check, separated by commas." env:"SFVW_INSTANCES"`
+	Version  string `help:"Expected release version." env:"SFVW_VERSION"`
+
+	ConfigFile string `help:"Path to a YAML config file declaring instances and overrides." short:"c" name:"config" type:"path"`
+
+	Daemon   bool          `help:"Run as a long-lived daemon that polls on an interval and serves /metrics, /healthz, and /status."`
+	Interval time.Duration `help:"Polling interval when running in --daemon mode (default 1m)." env:"SFVW_INTERVAL"`
+	Addr     string        `help:"Address to serve /metrics, /healthz, and /status on in --daemon mode (default :9090)." env:"SFVW_ADDR"`
+
+	Timeout        time.Duration `help:"Per-request timeout when calling the Salesforce status API (default 10s)." env:"SFVW_TIMEOUT"`
+	MaxConcurrency int           `help:"Maximum number of instances to check concurrently (default 8)." env:"SFVW_MAX_CONCURRENCY"`
+	MaxRetries     int           `help:"Maximum retry attempts per instance on network errors or 5xx responses (default 3)." env:"SFVW_MAX_RETRIES"`
+
+	MatchMode MatchMode `help:"How to compare the fetched release number against the expected version: exact, prefix, minimum, or range (default exact)." enum:",exact,prefix,minimum,range" env:"SFVW_MATCH_MODE"`
+
+	Output      OutputFormat `help:"Output format for check results: text, json, or ndjson (default text)." enum:",text,json,ndjson" env:"SFVW_OUTPUT"`
+	SummaryFile string       `help:"Path to write an aggregate JSON summary report to after all checks complete." type:"path"`
+}
+
+// These mirror the CLI struct's former kong `default:"..."` tags. They can no
+// longer live on the struct tags themselves: kong applies a `default` the
+// instant a flag/env var is absent, which made it indistinguishable from the
+// user explicitly setting that value, and silently defeated config-file
+// precedence for every global setting (see applyDefaults).
+const (
+	defaultInterval       = time.Minute
+	defaultAddr           = ":9090"
+	defaultTimeout        = 10 * time.Second
+	defaultMaxConcurrency = 8
+	defaultMaxRetries     = 3
+	defaultMatchMode      = MatchExact
+	defaultOutput         = OutputText
+)
+
+// applyDefaults fills in any global setting still at its zero value after
+// merging CLI flags and file config, i.e. one neither the user nor the config
+// file set explicitly.
+func applyDefaults(cli CLI) CLI {
+	if cli.Interval == 0 {
+		cli.Interval = defaultInterval
+	}
+	if cli.Addr == "" {
+		cli.Addr = defaultAddr
+	}
+	if cli.Timeout == 0 {
+		cli.Timeout = defaultTimeout
+	}
+	if cli.MaxConcurrency == 0 {
+		cli.MaxConcurrency = defaultMaxConcurrency
+	}
+	if cli.MaxRetries == 0 {
+		cli.MaxRetries = defaultMaxRetries
+	}
+	if cli.MatchMode == "" {
+		cli.MatchMode = defaultMatchMode
+	}
+	if cli.Output == "" {
+		cli.Output = defaultOutput
+	}
+	return cli
+}
+
+// InstanceConfig describes a single instance to watch, along with any
+// per-instance overrides of the global defaults.
+type InstanceConfig struct {
+	Instance        string        `yaml:"instance"`
+	ExpectedVersion string        `yaml:"expected_version,omitempty"`
+	PollInterval    time.Duration `yaml:"poll_interval,omitempty"`
+	Timeout         time.Duration `yaml:"timeout,omitempty"`
+	MatchMode       MatchMode     `yaml:"match_mode,omitempty"`
+}
+
+// FileConfig is the shape of the --config YAML file: a list of instances plus
+// the same global settings exposed on the CLI.
+type FileConfig struct {
+	Instances []InstanceConfig `yaml:"instances"`
+
+	Version string `yaml:"version,omitempty"`
+
+	Daemon   bool          `yaml:"daemon,omitempty"`
+	Interval time.Duration `yaml:"interval,omitempty"`
+	Addr     string        `yaml:"addr,omitempty"`
+
+	Timeout        time.Duration `yaml:"timeout,omitempty"`
+	MaxConcurrency int           `yaml:"max_concurrency,omitempty"`
+	MaxRetries     int           `yaml:"max_retries,omitempty"`
+	MatchMode      MatchMode     `yaml:"match_mode,omitempty"`
+
+	Notifications NotificationsConfig `yaml:"notifications,omitempty"`
+}
+
+// NotificationsConfig declares the notify.Sink implementations to fan
+// mismatch/error events out to. Each section is optional; omitted sections
+// produce no sink.
+type NotificationsConfig struct {
+	Slack   *SlackConfig   `yaml:"slack,omitempty"`
+	Discord *DiscordConfig `yaml:"discord,omitempty"`
+	Webhook *WebhookConfig `yaml:"webhook,omitempty"`
+	SMTP    *SMTPConfig    `yaml:"smtp,omitempty"`
+	AMQP    *AMQPConfig    `yaml:"amqp,omitempty"`
+}
+
+// SlackConfig configures notify.SlackSink.
+type SlackConfig struct {
+	WebhookURL string `yaml:"webhook_url"`
+}
+
+// DiscordConfig configures notify.DiscordSink.
+type DiscordConfig struct {
+	WebhookURL string `yaml:"webhook_url"`
+}
+
+// WebhookConfig configures notify.WebhookSink.
+type WebhookConfig struct {
+	URL string `yaml:"url"`
+}
+
+// SMTPConfig configures notify.SMTPSink.
+type SMTPConfig struct {
+	Host     string   `yaml:"host"`
+	Port     int      `yaml:"port"`
+	Username string   `yaml:"username,omitempty"`
+	Password string   `yaml:"password,omitempty"`
+	From     string   `yaml:"from"`
+	To       []string `yaml:"to"`
+}
+
+// AMQPConfig configures notify.AMQPSink.
+type AMQPConfig struct {
+	URI        string `yaml:"uri"`
+	Exchange   string `yaml:"exchange"`
+	RoutingKey string `yaml:"routing_key"`
+}
+
+// buildSinks constructs a notify.Sink for each configured notification
+// section.
+func buildSinks(cfg NotificationsConfig) []notify.Sink {
+	var sinks []notify.Sink
+	if cfg.Slack != nil {
+		sinks = append(sinks, notify.NewSlackSink(cfg.Slack.WebhookURL))
+	}
+	if cfg.Discord != nil {
+		sinks = append(sinks, notify.NewDiscordSink(cfg.Discord.WebhookURL))
+	}
+	if cfg.Webhook != nil {
+		sinks = append(sinks, notify.NewWebhookSink(cfg.Webhook.URL))
+	}
+	if cfg.SMTP != nil {
+		sinks = append(sinks, &notify.SMTPSink{
+			Host:     cfg.SMTP.Host,
+			Port:     cfg.SMTP.Port,
+			Username: cfg.SMTP.Username,
+			Password: cfg.SMTP.Password,
+			From:     cfg.SMTP.From,
+			To:       cfg.SMTP.To,
+		})
+	}
+	if cfg.AMQP != nil {
+		sinks = append(sinks, notify.NewAMQPSink(cfg.AMQP.URI, cfg.AMQP.Exchange, cfg.AMQP.RoutingKey))
+	}
+	return sinks
+}
+
+// loadFileConfig reads and parses a YAML config file. A missing path returns
+// a zero-value FileConfig rather than an error, since --config is optional.
+func loadFileConfig(path string) (*FileConfig, error) {
+	if path == "" {
+		return &FileConfig{}, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var cfg FileConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	return &cfg, nil
+}
+
+// resolveInstances merges the CLI flags and file config into the final list
+// of instances to watch. Instances declared in the config file take
+// precedence over the --instance/--version flags; the --instance/--version
+// flags are only used as a fallback for users who don't have a config file.
+func resolveInstances(cli CLI, file *FileConfig) []InstanceConfig {
+	if len(file.Instances) > 0 {
+		resolved := make([]InstanceConfig, len(file.Instances))
+		for i, ic := range file.Instances {
+			if ic.ExpectedVersion == "" {
+				ic.ExpectedVersion = file.Version
+			}
+			if ic.MatchMode == "" {
+				ic.MatchMode = cli.MatchMode
+			}
+			resolved[i] = ic
+		}
+		return resolved
+	}
+
+	var resolved []InstanceConfig
+	for _, name := range filterEmptyStrings(strings.Split(cli.Instance, ",")) {
+		resolved = append(resolved, InstanceConfig{Instance: name, ExpectedVersion: cli.Version, MatchMode: cli.MatchMode})
+	}
+	return resolved
+}
+
+// mergeGlobals applies config-file values to any CLI field left unset (the Go
+// zero value, since the `default:` kong tags were dropped from CLI), then
+// fills whatever is still unset with the package defaults via applyDefaults.
+// This gives the documented precedence: CLI flag/env var > config file >
+// default.
+func mergeGlobals(cli CLI, file *FileConfig) CLI {
+	if !cli.Daemon && file.Daemon {
+		cli.Daemon = file.Daemon
+	}
+	if cli.Interval == 0 && file.Interval != 0 {
+		cli.Interval = file.Interval
+	}
+	if cli.Addr == "" && file.Addr != "" {
+		cli.Addr = file.Addr
+	}
+	if cli.Timeout == 0 && file.Timeout != 0 {
+		cli.Timeout = file.Timeout
+	}
+	if cli.MaxConcurrency == 0 && file.MaxConcurrency != 0 {
+		cli.MaxConcurrency = file.MaxConcurrency
+	}
+	if cli.MaxRetries == 0 && file.MaxRetries != 0 {
+		cli.MaxRetries = file.MaxRetries
+	}
+	if cli.MatchMode == "" && file.MatchMode != "" {
+		cli.MatchMode = file.MatchMode
+	}
+	return applyDefaults(cli)
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMergeGlobals_PrecedenceCLIOverFileOverDefault(t *testing.T) {
+	tests := []struct {
+		name string
+		cli  CLI
+		file *FileConfig
+		want CLI
+	}{
+		{
+			name: "file config fills an unset CLI field",
+			cli:  CLI{},
+			file: &FileConfig{Timeout: 30 * time.Second},
+			want: CLI{Timeout: 30 * time.Second, Interval: defaultInterval, Addr: defaultAddr, MaxConcurrency: defaultMaxConcurrency, MaxRetries: defaultMaxRetries, MatchMode: defaultMatchMode, Output: defaultOutput},
+		},
+		{
+			name: "explicit CLI value wins over file config",
+			cli:  CLI{Timeout: 5 * time.Second},
+			file: &FileConfig{Timeout: 30 * time.Second},
+			want: CLI{Timeout: 5 * time.Second, Interval: defaultInterval, Addr: defaultAddr, MaxConcurrency: defaultMaxConcurrency, MaxRetries: defaultMaxRetries, MatchMode: defaultMatchMode, Output: defaultOutput},
+		},
+		{
+			name: "nothing set anywhere falls back to defaults",
+			cli:  CLI{},
+			file: &FileConfig{},
+			want: CLI{Interval: defaultInterval, Addr: defaultAddr, Timeout: defaultTimeout, MaxConcurrency: defaultMaxConcurrency, MaxRetries: defaultMaxRetries, MatchMode: defaultMatchMode, Output: defaultOutput},
+		},
+		{
+			name: "file config sets match mode when CLI leaves it unset",
+			cli:  CLI{},
+			file: &FileConfig{MatchMode: MatchRange},
+			want: CLI{Interval: defaultInterval, Addr: defaultAddr, Timeout: defaultTimeout, MaxConcurrency: defaultMaxConcurrency, MaxRetries: defaultMaxRetries, MatchMode: MatchRange, Output: defaultOutput},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeGlobals(tt.cli, tt.file)
+			if got != tt.want {
+				t.Fatalf("mergeGlobals() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveInstances_FileInstancesTakePrecedence(t *testing.T) {
+	cli := CLI{Instance: "na1,na2", Version: "250.1.0", MatchMode: MatchExact}
+	file := &FileConfig{
+		Version: "250.2.0",
+		Instances: []InstanceConfig{
+			{Instance: "eu1"},
+			{Instance: "eu2", ExpectedVersion: "250.3.0", MatchMode: MatchPrefix},
+		},
+	}
+
+	got := resolveInstances(cli, file)
+	if len(got) != 2 {
+		t.Fatalf("resolveInstances() returned %d instances, want 2", len(got))
+	}
+	if got[0].Instance != "eu1" || got[0].ExpectedVersion != "250.2.0" || got[0].MatchMode != MatchExact {
+		t.Fatalf("got[0] = %+v, want version/match mode inherited from file/CLI defaults", got[0])
+	}
+	if got[1].Instance != "eu2" || got[1].ExpectedVersion != "250.3.0" || got[1].MatchMode != MatchPrefix {
+		t.Fatalf("got[1] = %+v, want its own explicit overrides preserved", got[1])
+	}
+}
+
+func TestResolveInstances_FallsBackToCLIFlags(t *testing.T) {
+	cli := CLI{Instance: "na1, ,na2", Version: "250.1.0", MatchMode: MatchMinimum}
+	file := &FileConfig{}
+
+	got := resolveInstances(cli, file)
+	if len(got) != 2 {
+		t.Fatalf("resolveInstances() returned %d instances, want 2 (blank entry filtered)", len(got))
+	}
+	for _, ic := range got {
+		if ic.ExpectedVersion != "250.1.0" || ic.MatchMode != MatchMinimum {
+			t.Fatalf("instance %+v did not inherit CLI version/match mode", ic)
+		}
+	}
+}
@@ -1,83 +1,76 @@
 package main
 
 import (
-	"encoding/json"
-	"flag"
-	"fmt"
+	"context"
 	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
 	"strings"
 	"sync"
+	"syscall"
+	"time"
+
+	"github.com/alecthomas/kong"
+	"github.com/dmoruzzi/sf-version-watch/notify"
 )
 
-// MinimalAPIResponse retrieves thereleaseNumber API response
-type MinimalAPIResponse struct {
-	ReleaseNumber string `json:"releaseNumber"`
-}
+// main handles command-line arguments, starts parallel processing, and collects results
+func main() {
+	var cli CLI
+	kong.Parse(&cli,
+		kong.Name("sf-version-watch"),
+		kong.Description("Watches Salesforce instance release numbers for drift from an expected version."),
+	)
 
-// FetchStatus retrieves the release number for a given instance from the Salesforce API
-func fetchStatus(instance string) (string, error) {
-	url := fmt.Sprintf("https://status.salesforce.com/api/instances/%s/status/preview?locale=en", instance)
-	resp, err := http.Get(url)
+	fileConfig, err := loadFileConfig(cli.ConfigFile)
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch status: %w", err)
+		slog.Error("failed to load config file", "error", err)
+		os.Exit(1)
 	}
-	defer resp.Body.Close()
+	cli = mergeGlobals(cli, fileConfig)
 
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("API returned non-OK status: %s", resp.Status)
+	instances := resolveInstances(cli, fileConfig)
+	if len(instances) == 0 {
+		slog.Error("no valid instances provided via --instance, SFVW_INSTANCES, or --config")
+		os.Exit(1)
 	}
 
-	var apiResponse MinimalAPIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
-		return "", fmt.Errorf("failed to decode response: %w", err)
+	checker := &Checker{
+		HTTPClient: http.DefaultClient,
+		Timeout:    cli.Timeout,
+		Retry:      RetryPolicy{MaxAttempts: cli.MaxRetries, BaseDelay: DefaultRetryPolicy.BaseDelay, MaxDelay: DefaultRetryPolicy.MaxDelay},
 	}
+	notifier := notify.NewNotifier(buildSinks(fileConfig.Notifications)...)
 
-	return apiResponse.ReleaseNumber, nil
-}
-
-// compareReleaseNumbers compares the fetched release number with the expected version
-func compareReleaseNumbers(instance, expectedVersion string, wg *sync.WaitGroup, results chan<- string) {
-	defer wg.Done()
-
-	releaseNumber, err := fetchStatus(instance)
-	if err != nil {
-		results <- fmt.Sprintf("Error fetching status for instance %s: %s", instance, err)
+	if cli.Daemon {
+		runDaemon(checker, notifier, instances, cli.Interval, cli.Addr, cli.MaxConcurrency)
 		return
 	}
 
-	if releaseNumber != expectedVersion {
-		results <- fmt.Sprintf("Release number mismatch for instance %s: expected %s, got %s", instance, expectedVersion, releaseNumber)
-	} else {
-		results <- fmt.Sprintf("Release number matches for instance %s: %s", instance, releaseNumber)
-	}
-}
-
-// main handles command-line arguments, starts parallel processing, and collects results
-func main() {
-	instanceFlag := flag.String("instance", "", "Specify the instance name(s), separated by commas")
-	versionFlag := flag.String("version", "", "Specify the version")
-	flag.Parse()
-
-	if *instanceFlag == "" || *versionFlag == "" {
-		slog.Error("Instance and version flags are required")
-		flag.Usage()
+	if !runOnce(checker, notifier, instances, cli.MaxConcurrency, cli.Output, cli.SummaryFile) {
 		os.Exit(1)
 	}
+}
 
-	instances := parseInstances(*instanceFlag)
-	if len(instances) == 0 {
-		slog.Error("No valid instances provided")
-		os.Exit(1)
-	}
+// runOnce performs a single round of checks across instances, bounded to
+// maxConcurrency in flight at a time, renders each result per output, and
+// writes an aggregate report to summaryFile if set. It returns false if any
+// instance mismatched or errored.
+func runOnce(checker *Checker, notifier *notify.Notifier, instances []InstanceConfig, maxConcurrency int, output OutputFormat, summaryFile string) bool {
+	ctx := context.Background()
+	sem := make(chan struct{}, maxConcurrency)
 
 	var wg sync.WaitGroup
-	results := make(chan string, len(instances))
+	results := make(chan Result, len(instances))
 
 	for _, instance := range instances {
 		wg.Add(1)
-		go compareReleaseNumbers(instance, *versionFlag, &wg, results)
+		go func(instance InstanceConfig) {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			compareReleaseNumbers(ctx, checker, instance.Instance, instance.ExpectedVersion, instance.MatchMode, instance.Timeout, &wg, results)
+		}(instance)
 	}
 
 	go func() {
@@ -85,15 +78,85 @@ func main() {
 		close(results)
 	}()
 
-	if !processResults(results) {
-		os.Exit(1)
+	writer := NewResultWriter(os.Stdout, output)
+	exitHealthy := processResults(results, notifier, writer)
+	records := writer.Close()
+
+	if err := writeSummaryFile(summaryFile, records); err != nil {
+		slog.Error("failed to write summary file", "error", err)
 	}
+
+	return exitHealthy
 }
 
-// parseInstances splits and trims the instance names from the command-line flag
-func parseInstances(instanceFlag string) []string {
-	instances := strings.Split(instanceFlag, ",")
-	return filterEmptyStrings(instances)
+// runDaemon polls each instance on its own ticker, defaulting to interval but
+// honoring InstanceConfig.PollInterval when an instance sets one, updating
+// Prometheus metrics and the /status snapshot after every check, until it
+// receives SIGINT/SIGTERM.
+func runDaemon(checker *Checker, notifier *notify.Notifier, instances []InstanceConfig, interval time.Duration, addr string, maxConcurrency int) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	server := newStatusServer()
+	go func() {
+		if err := server.serve(ctx, addr); err != nil {
+			slog.Error("status server stopped", "error", err)
+		}
+	}()
+
+	sem := make(chan struct{}, maxConcurrency)
+
+	var wg sync.WaitGroup
+	for _, instance := range instances {
+		wg.Add(1)
+		go func(instance InstanceConfig) {
+			defer wg.Done()
+			pollInstance(ctx, checker, notifier, server, sem, instance, interval)
+		}(instance)
+	}
+
+	wg.Wait()
+	slog.Info("daemon shutting down")
+}
+
+// pollInstance checks a single instance immediately, then again every
+// instance.PollInterval (or the global interval, if unset) until ctx is
+// canceled. sem bounds how many instances across the whole daemon may be
+// fetching concurrently at once.
+func pollInstance(ctx context.Context, checker *Checker, notifier *notify.Notifier, server *statusServer, sem chan struct{}, instance InstanceConfig, interval time.Duration) {
+	pollInterval := instance.PollInterval
+	if pollInterval == 0 {
+		pollInterval = interval
+	}
+
+	check := func() {
+		sem <- struct{}{}
+		defer func() { <-sem }()
+
+		start := time.Now()
+		result := checker.Check(ctx, instance.Instance, instance.ExpectedVersion, instance.MatchMode, instance.Timeout)
+		observeResult(result, time.Since(start))
+
+		logResult(result)
+		server.setResult(result)
+		if !result.Matched() {
+			notifier.Notify(ctx, result.toEvent())
+		}
+	}
+
+	check()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			check()
+		}
+	}
 }
 
 // filterEmptyStrings removes empty or whitespace-only strings from a slice
@@ -107,13 +170,28 @@ func filterEmptyStrings(slice []string) []string {
 	return filtered
 }
 
-// processResults reads results from the channel and logs them
-func processResults(results <-chan string) bool {
+// logResult logs a single check result at the appropriate level.
+func logResult(result Result) {
+	if result.Err != nil {
+		slog.Debug("error fetching status", "instance", result.Instance, "error", result.Err, "attempts", result.Attempts)
+		return
+	}
+	if !result.Matched() {
+		slog.Debug("release number mismatch", "instance", result.Instance, "expected", result.Expected, "got", result.Got, "attempts", result.Attempts)
+		return
+	}
+	slog.Debug("release number matches", "instance", result.Instance, "version", result.Got, "attempts", result.Attempts)
+}
+
+// processResults reads results from the channel, renders them via writer,
+// and notifies notifier of any mismatch or error.
+func processResults(results <-chan Result, notifier *notify.Notifier, writer *ResultWriter) bool {
 	exitHealthy := true
 	for result := range results {
-		slog.Debug(result)
-		if strings.Contains(result, "mismatch") {
+		writer.Write(result)
+		if !result.Matched() {
 			exitHealthy = false
+			notifier.Notify(context.Background(), result.toEvent())
 		}
 	}
 	return exitHealthy
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MatchMode selects how a fetched release number is compared against the
+// expected version.
+type MatchMode string
+
+const (
+	// MatchExact requires the release number to equal the expected version
+	// exactly. This is the original, and still default, behavior.
+	MatchExact MatchMode = "exact"
+	// MatchPrefix requires the release number to start with the expected
+	// version, e.g. expected "250" matches got "250.15.6".
+	MatchPrefix MatchMode = "prefix"
+	// MatchMinimum requires the release number to be greater than or equal
+	// to the expected version under Release.Compare.
+	MatchMinimum MatchMode = "minimum"
+	// MatchRange requires the release number to satisfy a comma-separated
+	// list of constraints, e.g. ">=250.10,<251".
+	MatchRange MatchMode = "range"
+)
+
+// Release is a Salesforce release number (e.g. "250.15.6") parsed into its
+// dot-separated numeric segments, so releases can be ordered rather than only
+// compared for string equality.
+type Release []int
+
+// ParseRelease splits s on "." and parses each segment as an integer.
+func ParseRelease(s string) (Release, error) {
+	segments := strings.Split(s, ".")
+	release := make(Release, len(segments))
+	for i, seg := range segments {
+		n, err := strconv.Atoi(strings.TrimSpace(seg))
+		if err != nil {
+			return nil, fmt.Errorf("invalid release segment %q in %q: %w", seg, s, err)
+		}
+		release[i] = n
+	}
+	return release, nil
+}
+
+// Compare returns -1, 0, or 1 as r is less than, equal to, or greater than
+// other. Shorter releases are zero-padded to the longer release's length
+// before comparing, e.g. "250" compares equal to "250.0.0".
+func (r Release) Compare(other Release) int {
+	length := len(r)
+	if len(other) > length {
+		length = len(other)
+	}
+	for i := 0; i < length; i++ {
+		a, b := segmentAt(r, i), segmentAt(other, i)
+		if a != b {
+			if a < b {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func segmentAt(r Release, i int) int {
+	if i >= len(r) {
+		return 0
+	}
+	return r[i]
+}
+
+// rangeConstraint is a single "<op><release>" term within a --match-mode=range
+// expression.
+type rangeConstraint struct {
+	op      string
+	release Release
+}
+
+var rangeOperators = []string{">=", "<=", ">", "<", "=="}
+
+// parseRange parses a comma-separated list of constraints such as
+// ">=250.10,<251" into rangeConstraints, ANDed together.
+func parseRange(expr string) ([]rangeConstraint, error) {
+	var constraints []rangeConstraint
+	for _, term := range strings.Split(expr, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+
+		op, rest, err := splitOperator(term)
+		if err != nil {
+			return nil, err
+		}
+
+		release, err := ParseRelease(rest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid range term %q: %w", term, err)
+		}
+		constraints = append(constraints, rangeConstraint{op: op, release: release})
+	}
+	if len(constraints) == 0 {
+		return nil, fmt.Errorf("empty range expression %q", expr)
+	}
+	return constraints, nil
+}
+
+func splitOperator(term string) (op, rest string, err error) {
+	for _, candidate := range rangeOperators {
+		if strings.HasPrefix(term, candidate) {
+			return candidate, strings.TrimSpace(strings.TrimPrefix(term, candidate)), nil
+		}
+	}
+	return "", "", fmt.Errorf("range term %q is missing a comparison operator (>=, <=, >, <, ==)", term)
+}
+
+// satisfies reports whether release satisfies every constraint.
+func satisfies(release Release, constraints []rangeConstraint) bool {
+	for _, c := range constraints {
+		cmp := release.Compare(c.release)
+		switch c.op {
+		case ">=":
+			if cmp < 0 {
+				return false
+			}
+		case "<=":
+			if cmp > 0 {
+				return false
+			}
+		case ">":
+			if cmp <= 0 {
+				return false
+			}
+		case "<":
+			if cmp >= 0 {
+				return false
+			}
+		case "==":
+			if cmp != 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// matchRelease reports whether got matches expected under mode. An error is
+// returned only when expected/got can't be parsed under a mode that requires
+// numeric comparison.
+func matchRelease(mode MatchMode, expected, got string) (bool, error) {
+	switch mode {
+	case MatchPrefix:
+		return strings.HasPrefix(got, expected), nil
+
+	case MatchMinimum:
+		expectedRelease, err := ParseRelease(expected)
+		if err != nil {
+			return false, err
+		}
+		gotRelease, err := ParseRelease(got)
+		if err != nil {
+			return false, err
+		}
+		return gotRelease.Compare(expectedRelease) >= 0, nil
+
+	case MatchRange:
+		constraints, err := parseRange(expected)
+		if err != nil {
+			return false, err
+		}
+		gotRelease, err := ParseRelease(got)
+		if err != nil {
+			return false, err
+		}
+		return satisfies(gotRelease, constraints), nil
+
+	default:
+		return got == expected, nil
+	}
+}
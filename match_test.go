@@ -0,0 +1,108 @@
+package main
+
+import "testing"
+
+func TestReleaseCompare(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want int
+	}{
+		{"equal", "250.1.0", "250.1.0", 0},
+		{"less", "250.1.0", "250.2.0", -1},
+		{"greater", "250.2.0", "250.1.0", 1},
+		{"shorter zero-padded equal", "250", "250.0.0", 0},
+		{"shorter zero-padded less", "250", "250.0.1", -1},
+		{"major wins over minor/patch", "249.9.9", "250.0.0", -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := ParseRelease(tt.a)
+			if err != nil {
+				t.Fatalf("ParseRelease(%q): %v", tt.a, err)
+			}
+			b, err := ParseRelease(tt.b)
+			if err != nil {
+				t.Fatalf("ParseRelease(%q): %v", tt.b, err)
+			}
+			if got := a.Compare(b); got != tt.want {
+				t.Fatalf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRelease_Invalid(t *testing.T) {
+	if _, err := ParseRelease("250.x.0"); err == nil {
+		t.Fatal("expected an error parsing a non-numeric segment, got nil")
+	}
+}
+
+func TestMatchRelease(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     MatchMode
+		expected string
+		got      string
+		want     bool
+		wantErr  bool
+	}{
+		{"exact match", MatchExact, "250.1.0", "250.1.0", true, false},
+		{"exact mismatch", MatchExact, "250.1.0", "250.1.1", false, false},
+		{"prefix match", MatchPrefix, "250", "250.15.6", true, false},
+		{"prefix mismatch", MatchPrefix, "251", "250.15.6", false, false},
+		{"minimum satisfied", MatchMinimum, "250.10.0", "250.15.6", true, false},
+		{"minimum equal", MatchMinimum, "250.15.6", "250.15.6", true, false},
+		{"minimum unsatisfied", MatchMinimum, "250.15.6", "250.10.0", false, false},
+		{"minimum invalid expected", MatchMinimum, "not-a-release", "250.15.6", false, true},
+		{"range satisfied", MatchRange, ">=250.10,<251", "250.15.6", true, false},
+		{"range unsatisfied upper bound", MatchRange, ">=250.10,<251", "251.0.0", false, false},
+		{"range unsatisfied lower bound", MatchRange, ">=250.10,<251", "250.5.0", false, false},
+		{"range invalid expression", MatchRange, ">=250.10,bogus", "250.15.6", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := matchRelease(tt.mode, tt.expected, tt.got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("matchRelease(%s, %q, %q) = %v, want %v", tt.mode, tt.expected, tt.got, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr bool
+	}{
+		{"valid multi-term", ">=250.10,<251", false},
+		{"valid single term", "==250.15.6", false},
+		{"empty expression", "", true},
+		{"missing operator", "250.15.6", true},
+		{"invalid release segment", ">=250.x", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseRange(tt.expr)
+			if tt.wantErr && err == nil {
+				t.Fatalf("parseRange(%q): expected an error, got nil", tt.expr)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("parseRange(%q): unexpected error: %v", tt.expr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	releaseMatches = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sfvw_release_matches",
+		Help: "1 if the instance's release number matches the expected version, 0 otherwise.",
+	}, []string{"instance", "expected"})
+
+	fetchErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "sfvw_fetch_errors_total",
+		Help: "Total number of errors encountered fetching an instance's status.",
+	}, []string{"instance"})
+
+	fetchDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "sfvw_fetch_duration_seconds",
+		Help:    "Time spent fetching an instance's status from the Salesforce API.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"instance"})
+)
+
+// observeResult updates the Prometheus metrics for a single check result.
+func observeResult(result Result, duration time.Duration) {
+	fetchDuration.WithLabelValues(result.Instance).Observe(duration.Seconds())
+
+	if result.Err != nil {
+		fetchErrorsTotal.WithLabelValues(result.Instance).Inc()
+		return
+	}
+
+	matches := 0.0
+	if result.Matched() {
+		matches = 1.0
+	}
+	releaseMatches.WithLabelValues(result.Instance, result.Expected).Set(matches)
+}
+
+// statusServer serves /metrics, /healthz, and /status for the daemon. latest
+// is keyed by instance name rather than held as a single slice because
+// instances can each poll on their own interval (InstanceConfig.PollInterval),
+// so results arrive independently rather than as one batch per round.
+type statusServer struct {
+	mu     sync.RWMutex
+	latest map[string]Result
+}
+
+func newStatusServer() *statusServer {
+	return &statusServer{latest: make(map[string]Result)}
+}
+
+// setResult records the most recent result for a single instance.
+func (s *statusServer) setResult(result Result) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latest[result.Instance] = result
+}
+
+func (s *statusServer) handleStatus(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	results := make([]Result, 0, len(s.latest))
+	for _, result := range s.latest {
+		results = append(results, result)
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Instance < results[j].Instance })
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(results); err != nil {
+		slog.Error("failed to encode status response", "error", err)
+	}
+}
+
+func (s *statusServer) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintln(w, "ok")
+}
+
+// serve starts the HTTP server exposing /metrics, /healthz, and /status and
+// blocks until ctx is canceled.
+func (s *statusServer) serve(ctx context.Context, addr string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/healthz", s.handleHealthz)
+	mux.HandleFunc("/status", s.handleStatus)
+
+	srv := &http.Server{Addr: addr, Handler: mux}
+
+	errCh := make(chan error, 1)
+	go func() {
+		slog.Info("serving metrics and status endpoints", "addr", addr)
+		errCh <- srv.ListenAndServe()
+	}()
+
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	case err := <-errCh:
+		return err
+	}
+}
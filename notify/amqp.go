@@ -0,0 +1,62 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// AMQPSink publishes the event as a JSON message to an AMQP exchange, e.g.
+// RabbitMQ.
+type AMQPSink struct {
+	URI          string
+	Exchange     string
+	RoutingKey   string
+	exchangeKind string
+}
+
+// NewAMQPSink returns an AMQPSink publishing to exchange/routingKey on the
+// broker at uri. The exchange is declared as "topic" if it doesn't already
+// exist.
+func NewAMQPSink(uri, exchange, routingKey string) *AMQPSink {
+	return &AMQPSink{URI: uri, Exchange: exchange, RoutingKey: routingKey, exchangeKind: "topic"}
+}
+
+func (s *AMQPSink) Name() string { return "amqp" }
+
+// Notify dials the broker, declares the exchange, and publishes event as a
+// JSON message. A fresh connection is opened per call: mismatches are rare
+// enough that a persistent channel isn't worth the added complexity.
+func (s *AMQPSink) Notify(ctx context.Context, event Event) error {
+	conn, err := amqp.DialConfig(s.URI, amqp.Config{})
+	if err != nil {
+		return fmt.Errorf("failed to connect to amqp broker: %w", err)
+	}
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("failed to open amqp channel: %w", err)
+	}
+	defer ch.Close()
+
+	if err := ch.ExchangeDeclare(s.Exchange, s.exchangeKind, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare amqp exchange: %w", err)
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	err = ch.PublishWithContext(ctx, s.Exchange, s.RoutingKey, false, false, amqp.Publishing{
+		ContentType: "application/json",
+		Body:        body,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish to amqp exchange: %w", err)
+	}
+	return nil
+}
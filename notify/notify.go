@@ -0,0 +1,186 @@
+// Package notify fans mismatch and error events out to one or more
+// configurable sinks (webhooks, email, AMQP, ...) so operators can route
+// alerts wherever they already watch for them.
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"math/rand"
+	"time"
+)
+
+// EventKind describes why an Event was raised.
+type EventKind string
+
+const (
+	// KindMismatch is raised when an instance's release number does not
+	// match what was expected.
+	KindMismatch EventKind = "mismatch"
+	// KindError is raised when an instance's status could not be fetched.
+	KindError EventKind = "error"
+)
+
+// Event describes a single mismatch or fetch error, ready to be rendered by
+// any Sink.
+type Event struct {
+	Instance  string
+	Expected  string
+	Got       string
+	Err       error
+	Timestamp time.Time
+	Kind      EventKind
+}
+
+// MarshalJSON renders Event for sinks (e.g. AMQP, webhooks) that publish it
+// as a JSON message; Err is flattened to its string message since error
+// values don't marshal meaningfully on their own.
+func (e Event) MarshalJSON() ([]byte, error) {
+	type alias struct {
+		Instance  string    `json:"instance"`
+		Expected  string    `json:"expected"`
+		Got       string    `json:"got,omitempty"`
+		Error     string    `json:"error,omitempty"`
+		Kind      EventKind `json:"kind"`
+		Timestamp time.Time `json:"timestamp"`
+	}
+	return json.Marshal(alias{
+		Instance:  e.Instance,
+		Expected:  e.Expected,
+		Got:       e.Got,
+		Error:     errString(e.Err),
+		Kind:      e.Kind,
+		Timestamp: e.Timestamp,
+	})
+}
+
+// Sink delivers an Event to some external system (chat, email, a message
+// broker, ...). Implementations should treat ctx's deadline as a hard limit
+// on the delivery attempt.
+type Sink interface {
+	Notify(ctx context.Context, event Event) error
+}
+
+// RetryPolicy configures the backoff used when a Sink's Notify call fails.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// DefaultRetryPolicy mirrors the backoff used for Salesforce API fetches:
+// a handful of attempts with exponential backoff and jitter.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    30 * time.Second,
+}
+
+// DefaultTimeout bounds each individual delivery attempt to a sink. Without
+// it, a sink that accepts a connection but never responds (a hung webhook
+// endpoint, a stalled SMTP handshake) would block its goroutine forever,
+// permanently consuming one of the caller's concurrency slots.
+const DefaultTimeout = 10 * time.Second
+
+// Notifier fans an Event out to every configured Sink concurrently. A sink
+// that fails all of its retries is logged and skipped; it never prevents
+// other sinks from receiving the event.
+type Notifier struct {
+	Sinks []Sink
+	Retry RetryPolicy
+	// Timeout bounds each individual delivery attempt. Zero falls back to
+	// DefaultTimeout; it is never unbounded, since Sink implementations may
+	// not honor ctx cancellation on their own (net/smtp has no context-aware
+	// API, and http.Client with no Timeout set will otherwise hang forever).
+	Timeout time.Duration
+}
+
+// NewNotifier builds a Notifier over the given sinks using DefaultRetryPolicy
+// and DefaultTimeout.
+func NewNotifier(sinks ...Sink) *Notifier {
+	return &Notifier{Sinks: sinks, Retry: DefaultRetryPolicy, Timeout: DefaultTimeout}
+}
+
+// Notify delivers event to every sink concurrently, retrying each sink
+// independently per n.Retry. It never returns an error: delivery failures are
+// logged, since a broken sink shouldn't stop the caller's main loop.
+func (n *Notifier) Notify(ctx context.Context, event Event) {
+	if len(n.Sinks) == 0 {
+		return
+	}
+
+	done := make(chan struct{}, len(n.Sinks))
+	for _, sink := range n.Sinks {
+		go func(sink Sink) {
+			defer func() { done <- struct{}{} }()
+			n.notifyWithRetry(ctx, sink, event)
+		}(sink)
+	}
+	for range n.Sinks {
+		<-done
+	}
+}
+
+func (n *Notifier) notifyWithRetry(ctx context.Context, sink Sink, event Event) {
+	policy := n.Retry
+	if policy.MaxAttempts <= 0 {
+		policy = DefaultRetryPolicy
+	}
+	timeout := n.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < policy.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			delay := backoff(policy, attempt)
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		if err := notifyWithTimeout(ctx, sink, event, timeout); err != nil {
+			lastErr = err
+			slog.Warn("notification sink failed, will retry", "sink", sinkName(sink), "attempt", attempt+1, "error", err)
+			continue
+		}
+		return
+	}
+
+	slog.Error("notification sink gave up after retries", "sink", sinkName(sink), "error", lastErr)
+}
+
+// notifyWithTimeout gives a single delivery attempt its own fresh deadline,
+// so one hung attempt can't consume the whole retry budget and, more
+// importantly, can't block its caller's goroutine forever.
+func notifyWithTimeout(ctx context.Context, sink Sink, event Event, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return sink.Notify(ctx, event)
+}
+
+func backoff(policy RetryPolicy, attempt int) time.Duration {
+	delay := policy.BaseDelay << uint(attempt-1)
+	if delay > policy.MaxDelay || delay <= 0 {
+		delay = policy.MaxDelay
+	}
+	jitter := time.Duration(rand.Int63n(int64(delay)/2 + 1))
+	return delay/2 + jitter
+}
+
+// sinkNamer is an optional interface a Sink can implement to identify itself
+// in logs; sinks that don't implement it are logged by their Go type name.
+type sinkNamer interface {
+	Name() string
+}
+
+func sinkName(sink Sink) string {
+	if named, ok := sink.(sinkNamer); ok {
+		return named.Name()
+	}
+	return "unknown"
+}
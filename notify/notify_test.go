@@ -0,0 +1,108 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeSink is a Sink whose behavior per call is scripted by fail, for
+// exercising Notifier's retry/backoff and fan-out logic without real network
+// I/O.
+type fakeSink struct {
+	name string
+	// fail returns an error for the given 0-indexed attempt number, or nil
+	// once it should succeed.
+	fail func(attempt int) error
+
+	mu       sync.Mutex
+	attempts int
+}
+
+func (s *fakeSink) Name() string { return s.name }
+
+func (s *fakeSink) Notify(ctx context.Context, event Event) error {
+	s.mu.Lock()
+	attempt := s.attempts
+	s.attempts++
+	s.mu.Unlock()
+	return s.fail(attempt)
+}
+
+func (s *fakeSink) Attempts() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.attempts
+}
+
+func fastRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+}
+
+func TestNotifier_RetriesUpToMaxAttempts(t *testing.T) {
+	sink := &fakeSink{name: "always-fails", fail: func(int) error { return errors.New("boom") }}
+	n := &Notifier{Sinks: []Sink{sink}, Retry: fastRetryPolicy(), Timeout: time.Second}
+
+	n.Notify(context.Background(), Event{Instance: "na1"})
+
+	if got := sink.Attempts(); got != 3 {
+		t.Fatalf("attempts = %d, want 3 (MaxAttempts)", got)
+	}
+}
+
+func TestNotifier_SucceedsAfterTransientFailures(t *testing.T) {
+	sink := &fakeSink{name: "flaky", fail: func(attempt int) error {
+		if attempt < 2 {
+			return errors.New("transient")
+		}
+		return nil
+	}}
+	n := &Notifier{Sinks: []Sink{sink}, Retry: fastRetryPolicy(), Timeout: time.Second}
+
+	n.Notify(context.Background(), Event{Instance: "na1"})
+
+	if got := sink.Attempts(); got != 3 {
+		t.Fatalf("attempts = %d, want 3 (2 failures then a success)", got)
+	}
+}
+
+func TestNotifier_OneFailingSinkDoesNotBlockOthers(t *testing.T) {
+	failing := &fakeSink{name: "failing", fail: func(int) error { return errors.New("boom") }}
+	succeeding := &fakeSink{name: "succeeding", fail: func(int) error { return nil }}
+	n := &Notifier{Sinks: []Sink{failing, succeeding}, Retry: fastRetryPolicy(), Timeout: time.Second}
+
+	n.Notify(context.Background(), Event{Instance: "na1"})
+
+	if got := failing.Attempts(); got != 3 {
+		t.Fatalf("failing sink attempts = %d, want 3", got)
+	}
+	if got := succeeding.Attempts(); got != 1 {
+		t.Fatalf("succeeding sink attempts = %d, want 1 (succeeded immediately)", got)
+	}
+}
+
+func TestNotifier_DeliveryAttemptTimesOutRatherThanHanging(t *testing.T) {
+	ctxSink := &ctxAwareSink{name: "hangs"}
+	n := &Notifier{Sinks: []Sink{ctxSink}, Retry: RetryPolicy{MaxAttempts: 1, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}, Timeout: 20 * time.Millisecond}
+
+	start := time.Now()
+	n.Notify(context.Background(), Event{Instance: "na1"})
+	elapsed := time.Since(start)
+
+	if elapsed > 500*time.Millisecond {
+		t.Fatalf("Notify took %v, want it to abandon the hung sink near its 20ms timeout", elapsed)
+	}
+}
+
+// ctxAwareSink blocks until its ctx is done, to verify Notifier actually
+// applies a deadline rather than relying on the sink to enforce one itself.
+type ctxAwareSink struct{ name string }
+
+func (s *ctxAwareSink) Name() string { return s.name }
+
+func (s *ctxAwareSink) Notify(ctx context.Context, event Event) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
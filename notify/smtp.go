@@ -0,0 +1,94 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+)
+
+// SMTPSink emails the rendered event to a fixed set of recipients.
+type SMTPSink struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+func (s *SMTPSink) Name() string { return "smtp" }
+
+// Notify sends event as a plain-text email. net/smtp.SendMail has no
+// context-aware API and no internal timeout of its own, so Notify dials the
+// connection with ctx via net.Dialer and, once ctx carries a deadline, also
+// applies it to the connection itself - bounding the whole SMTP handshake
+// rather than just the initial dial.
+func (s *SMTPSink) Notify(ctx context.Context, event Event) error {
+	addr := fmt.Sprintf("%s:%d", s.Host, s.Port)
+
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to dial smtp server: %w", err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return fmt.Errorf("failed to set smtp connection deadline: %w", err)
+		}
+	}
+
+	client, err := smtp.NewClient(conn, s.Host)
+	if err != nil {
+		return fmt.Errorf("failed to establish smtp session: %w", err)
+	}
+	defer client.Close()
+
+	if s.Username != "" {
+		if ok, _ := client.Extension("AUTH"); ok {
+			auth := smtp.PlainAuth("", s.Username, s.Password, s.Host)
+			if err := client.Auth(auth); err != nil {
+				return fmt.Errorf("failed to authenticate with smtp server: %w", err)
+			}
+		}
+	}
+
+	subject := fmt.Sprintf("sf-version-watch: %s %s", event.Instance, event.Kind)
+	body := eventSummary(event)
+	msg := []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s\r\n",
+		s.From, joinAddrs(s.To), subject, body))
+
+	if err := client.Mail(s.From); err != nil {
+		return fmt.Errorf("failed to set smtp sender: %w", err)
+	}
+	for _, to := range s.To {
+		if err := client.Rcpt(to); err != nil {
+			return fmt.Errorf("failed to add smtp recipient %q: %w", to, err)
+		}
+	}
+
+	wc, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to open smtp data stream: %w", err)
+	}
+	if _, err := wc.Write(msg); err != nil {
+		return fmt.Errorf("failed to write smtp message body: %w", err)
+	}
+	if err := wc.Close(); err != nil {
+		return fmt.Errorf("failed to finalize smtp message: %w", err)
+	}
+
+	return client.Quit()
+}
+
+func joinAddrs(addrs []string) string {
+	out := ""
+	for i, a := range addrs {
+		if i > 0 {
+			out += ", "
+		}
+		out += a
+	}
+	return out
+}
@@ -0,0 +1,145 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// defaultClient is used by every HTTP sink when no Client is set explicitly.
+// http.DefaultClient has no Timeout, which combined with a ctx that may carry
+// no deadline of its own would let a non-responding endpoint hang forever;
+// Notifier.Timeout normally bounds the call via ctx anyway, but this keeps
+// the sinks safe even when used directly outside a Notifier.
+var defaultClient = &http.Client{Timeout: 30 * time.Second}
+
+// WebhookSink POSTs a JSON payload describing the event to a generic HTTP
+// endpoint.
+type WebhookSink struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewWebhookSink returns a WebhookSink posting to url using defaultClient.
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{URL: url, Client: defaultClient}
+}
+
+func (s *WebhookSink) Name() string { return "webhook" }
+
+// webhookPayload is the body posted to a generic webhook.
+type webhookPayload struct {
+	Instance  string `json:"instance"`
+	Expected  string `json:"expected"`
+	Got       string `json:"got,omitempty"`
+	Error     string `json:"error,omitempty"`
+	Kind      string `json:"kind"`
+	Timestamp string `json:"timestamp"`
+}
+
+// Notify posts event as JSON to s.URL.
+func (s *WebhookSink) Notify(ctx context.Context, event Event) error {
+	return postJSON(ctx, s.client(), s.URL, webhookPayload{
+		Instance:  event.Instance,
+		Expected:  event.Expected,
+		Got:       event.Got,
+		Error:     errString(event.Err),
+		Kind:      string(event.Kind),
+		Timestamp: event.Timestamp.Format(timeLayout),
+	})
+}
+
+func (s *WebhookSink) client() *http.Client {
+	if s.Client == nil {
+		return defaultClient
+	}
+	return s.Client
+}
+
+// SlackSink posts a chat-message-shaped payload to a Slack incoming webhook.
+type SlackSink struct {
+	WebhookURL string
+	Client     *http.Client
+}
+
+// NewSlackSink returns a SlackSink posting to webhookURL using defaultClient.
+func NewSlackSink(webhookURL string) *SlackSink {
+	return &SlackSink{WebhookURL: webhookURL, Client: defaultClient}
+}
+
+func (s *SlackSink) Name() string { return "slack" }
+
+// Notify posts event to a Slack incoming webhook as {"text": "..."}.
+func (s *SlackSink) Notify(ctx context.Context, event Event) error {
+	client := s.Client
+	if client == nil {
+		client = defaultClient
+	}
+	return postJSON(ctx, client, s.WebhookURL, map[string]string{"text": eventSummary(event)})
+}
+
+// DiscordSink posts a chat-message-shaped payload to a Discord incoming
+// webhook.
+type DiscordSink struct {
+	WebhookURL string
+	Client     *http.Client
+}
+
+// NewDiscordSink returns a DiscordSink posting to webhookURL using defaultClient.
+func NewDiscordSink(webhookURL string) *DiscordSink {
+	return &DiscordSink{WebhookURL: webhookURL, Client: defaultClient}
+}
+
+func (s *DiscordSink) Name() string { return "discord" }
+
+// Notify posts event to a Discord incoming webhook as {"content": "..."}.
+func (s *DiscordSink) Notify(ctx context.Context, event Event) error {
+	client := s.Client
+	if client == nil {
+		client = defaultClient
+	}
+	return postJSON(ctx, client, s.WebhookURL, map[string]string{"content": eventSummary(event)})
+}
+
+const timeLayout = "2006-01-02T15:04:05Z07:00"
+
+func eventSummary(event Event) string {
+	if event.Kind == KindError {
+		return fmt.Sprintf("sf-version-watch: error checking %s: %s", event.Instance, errString(event.Err))
+	}
+	return fmt.Sprintf("sf-version-watch: %s expected %s but got %s", event.Instance, event.Expected, event.Got)
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, body any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to deliver webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned non-OK status: %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// OutputFormat selects how check results are rendered to stdout.
+type OutputFormat string
+
+const (
+	// OutputText prints the same human-readable lines as slog.Debug always
+	// has; this is the default so existing pipelines grepping log output
+	// keep working.
+	OutputText OutputFormat = "text"
+	// OutputJSON prints a single JSON array of every record once all checks
+	// complete.
+	OutputJSON OutputFormat = "json"
+	// OutputNDJSON prints one JSON record per line as each check completes,
+	// suitable for streaming into jq or another line-oriented consumer.
+	OutputNDJSON OutputFormat = "ndjson"
+)
+
+// recordStatus is the Status field of a Record.
+type recordStatus string
+
+const (
+	statusMatch    recordStatus = "match"
+	statusMismatch recordStatus = "mismatch"
+	statusError    recordStatus = "error"
+)
+
+// Record is the structured, machine-readable form of a Result.
+type Record struct {
+	Instance   string       `json:"instance"`
+	Expected   string       `json:"expected"`
+	Got        string       `json:"got,omitempty"`
+	Status     recordStatus `json:"status"`
+	Error      string       `json:"error,omitempty"`
+	DurationMs int64        `json:"duration_ms"`
+	Attempts   int          `json:"attempts"`
+	CheckedAt  time.Time    `json:"checked_at"`
+}
+
+// toRecord converts a Result into its structured Record form.
+func (r Result) toRecord() Record {
+	status := statusMatch
+	switch {
+	case r.Err != nil:
+		status = statusError
+	case !r.Matched():
+		status = statusMismatch
+	}
+	return Record{
+		Instance:   r.Instance,
+		Expected:   r.Expected,
+		Got:        r.Got,
+		Status:     status,
+		Error:      errString(r.Err),
+		DurationMs: r.Duration.Milliseconds(),
+		Attempts:   r.Attempts,
+		CheckedAt:  r.Timestamp,
+	}
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// ResultWriter renders Records to stdout as each check completes, in the
+// format selected by --output, and accumulates them for an optional
+// --summary-file report.
+type ResultWriter struct {
+	format  OutputFormat
+	out     io.Writer
+	records []Record
+}
+
+// NewResultWriter returns a ResultWriter printing to out in format. An
+// unrecognized format falls back to OutputText.
+func NewResultWriter(out io.Writer, format OutputFormat) *ResultWriter {
+	return &ResultWriter{out: out, format: format}
+}
+
+// Write renders a single Result according to the selected format. For
+// OutputText it prints one human-readable line to stdout; for OutputJSON it
+// buffers the record until Close; for OutputNDJSON it prints the record
+// immediately. logResult always runs alongside, for debug-level log output.
+func (w *ResultWriter) Write(result Result) {
+	logResult(result)
+
+	record := result.toRecord()
+	w.records = append(w.records, record)
+
+	switch w.format {
+	case OutputNDJSON:
+		w.writeJSONLine(record)
+	case OutputJSON:
+		// Buffered; emitted as an array by Close.
+	default:
+		w.writeTextLine(record)
+	}
+}
+
+// writeTextLine prints a single human-readable summary line for record.
+func (w *ResultWriter) writeTextLine(record Record) {
+	switch record.Status {
+	case statusError:
+		fmt.Fprintf(w.out, "%s: ERROR %s (attempts=%d): %s\n", record.Instance, record.Expected, record.Attempts, record.Error)
+	case statusMismatch:
+		fmt.Fprintf(w.out, "%s: MISMATCH expected=%s got=%s (attempts=%d)\n", record.Instance, record.Expected, record.Got, record.Attempts)
+	default:
+		fmt.Fprintf(w.out, "%s: OK %s (attempts=%d)\n", record.Instance, record.Got, record.Attempts)
+	}
+}
+
+func (w *ResultWriter) writeJSONLine(record Record) {
+	if err := json.NewEncoder(w.out).Encode(record); err != nil {
+		fmt.Fprintf(os.Stderr, "sf-version-watch: failed to encode ndjson record: %v\n", err)
+	}
+}
+
+// Close flushes any buffered output (the JSON array, for OutputJSON) and
+// returns the accumulated records for a --summary-file report.
+func (w *ResultWriter) Close() []Record {
+	if w.format == OutputJSON {
+		if err := json.NewEncoder(w.out).Encode(w.records); err != nil {
+			fmt.Fprintf(os.Stderr, "sf-version-watch: failed to encode json output: %v\n", err)
+		}
+	}
+	return w.records
+}
+
+// Summary is the aggregate report written to --summary-file.
+type Summary struct {
+	Total     int       `json:"total"`
+	Matched   int       `json:"matched"`
+	Mismatch  int       `json:"mismatch"`
+	Errors    int       `json:"errors"`
+	CheckedAt time.Time `json:"checked_at"`
+	Records   []Record  `json:"records"`
+}
+
+// summarize aggregates records into a Summary.
+func summarize(records []Record) Summary {
+	summary := Summary{Total: len(records), CheckedAt: time.Now(), Records: records}
+	for _, r := range records {
+		switch r.Status {
+		case statusMatch:
+			summary.Matched++
+		case statusMismatch:
+			summary.Mismatch++
+		case statusError:
+			summary.Errors++
+		}
+	}
+	return summary
+}
+
+// writeSummaryFile writes an aggregate Summary of records as JSON to path. A
+// blank path is a no-op, since --summary-file is optional.
+func writeSummaryFile(path string, records []Record) error {
+	if path == "" {
+		return nil
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create summary file: %w", err)
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(summarize(records)); err != nil {
+		return fmt.Errorf("failed to write summary file: %w", err)
+	}
+	return nil
+}
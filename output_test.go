@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestResultWriter_TextModePrintsVisibleOutput verifies the default text
+// format actually writes a human-readable line to stdout for every case,
+// since it previously produced no visible output at all.
+func TestResultWriter_TextModePrintsVisibleOutput(t *testing.T) {
+	tests := []struct {
+		name   string
+		result Result
+		want   string
+	}{
+		{
+			name:   "match",
+			result: Result{Instance: "na1", Expected: "250.1.0", Got: "250.1.0", matched: true},
+			want:   "na1: OK 250.1.0",
+		},
+		{
+			name:   "mismatch",
+			result: Result{Instance: "na1", Expected: "250.1.0", Got: "249.9.0", matched: false},
+			want:   "na1: MISMATCH expected=250.1.0 got=249.9.0",
+		},
+		{
+			name:   "error",
+			result: Result{Instance: "na1", Expected: "250.1.0", Err: errors.New("boom")},
+			want:   "na1: ERROR 250.1.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			writer := NewResultWriter(&buf, OutputText)
+			writer.Write(tt.result)
+
+			if got := buf.String(); !strings.Contains(got, tt.want) {
+				t.Fatalf("text output = %q, want it to contain %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestResultWriter_UnrecognizedFormatFallsBackToText verifies an empty or
+// unrecognized OutputFormat still produces visible stdout output.
+func TestResultWriter_UnrecognizedFormatFallsBackToText(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewResultWriter(&buf, OutputFormat("bogus"))
+	writer.Write(Result{Instance: "na1", Expected: "250.1.0", Got: "250.1.0", matched: true})
+
+	if buf.Len() == 0 {
+		t.Fatal("expected visible output for an unrecognized format, got none")
+	}
+}